@@ -0,0 +1,156 @@
+package crud
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/uptrace/bun/schema"
+)
+
+// Cache is a pluggable read-through cache for Get, GetBy and Exists.
+// Implementations must be safe for concurrent use; see the crud/cache/memory
+// and crud/cache/redis subpackages for ready-made adapters.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Del(ctx context.Context, keys ...string) error
+}
+
+// WithCache makes Get, GetBy and Exists read-through against cache, and
+// makes Create/Update/Delete invalidate the entries they affect (Delete
+// only the primary-key entries; see WithCacheIndexes). Cached
+// values expire after ttl (zero means the cache's own default, if any).
+//
+// Get and Exists only cache calls whose Filter is a single "col = value"
+// condition and nothing else (see cacheableFilter) - the only shape
+// Create/Update/Delete can reconstruct and invalidate. A filter with
+// several conditions, an Or/And group, or Limit/OrderBy/Cursor/WithTotal
+// set bypasses the cache entirely rather than risk being served stale
+// forever. GetBy always caches, since it's col/value already.
+func WithCache[T any](cache Cache, ttl time.Duration) Option[T] {
+	return func(c *CRUD[T]) {
+		c.cache = cache
+		c.cacheTTL = ttl
+	}
+}
+
+// WithCacheIndexes registers additional columns (e.g. "email", "slug")
+// besides the primary key whose Get/Exists/GetBy lookups are also
+// invalidated when Create or Update writes a record. Delete and DeleteMany
+// evict only primary-key entries (see invalidateIDs): lookups by these
+// columns cached before a delete are not evicted by it.
+func WithCacheIndexes[T any](columns ...string) Option[T] {
+	return func(c *CRUD[T]) { c.cacheIndexes = columns }
+}
+
+// cacheKey canonicalizes parts - typically a table name, an operation tag,
+// and a Filter or a field/value pair - into an opaque cache key.
+func cacheKey(parts ...any) (string, error) {
+	data, err := json.Marshal(parts)
+	if err != nil {
+		return "", fmt.Errorf("crud: cache key: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// cached serves fn's result from cache on a hit, otherwise calls fn and
+// populates the cache with its result.
+func cached[T any](ctx context.Context, cache Cache, ttl time.Duration, key string, fn func() (T, error)) (T, error) {
+	if data, ok, err := cache.Get(ctx, key); err == nil && ok {
+		var result T
+		if err := json.Unmarshal(data, &result); err == nil {
+			return result, nil
+		}
+	}
+	result, err := fn()
+	if err != nil {
+		return result, err
+	}
+	if data, err := json.Marshal(result); err == nil {
+		_ = cache.Set(ctx, key, data, ttl)
+	}
+	return result, nil
+}
+
+// cacheableFilter reports whether filter is exactly the single-column
+// equality shape - Filter{Where: []Condition{Eq(col, value)}}, nothing else
+// set - that invalidateRow/invalidateIDs reconstruct via lookupKeys. Get and
+// Exists only cache under this shape: any other filter (a multi-condition
+// or grouped Where, Limit/OrderBy/Cursor/WithTotal set, ...) would be cached
+// under a key Create/Update/Delete can never reproduce, so it would never
+// be invalidated and could be served stale indefinitely.
+func cacheableFilter(filter Filter) (col string, value any, ok bool) {
+	if len(filter.Where) != 1 || filter.Where[0].Op != OpEq {
+		return "", nil, false
+	}
+	cond := filter.Where[0]
+	if !reflect.DeepEqual(filter, Filter{Where: []Condition{Eq(cond.Field, cond.Value)}}) {
+		return "", nil, false
+	}
+	return cond.Field, cond.Value, true
+}
+
+// lookupKeys returns the cache keys Get, Exists and GetBy would use for a
+// "col = value" lookup.
+func (c *CRUD[T]) lookupKeys(table *schema.Table, col string, value any) []string {
+	keys := make([]string, 0, 3)
+	if key, err := cacheKey(table.Name, "get", Filter{Where: []Condition{Eq(col, value)}}); err == nil {
+		keys = append(keys, key)
+	}
+	if key, err := cacheKey(table.Name, "exists", Filter{Where: []Condition{Eq(col, value)}}); err == nil {
+		keys = append(keys, key)
+	}
+	if key, err := cacheKey(table.Name, "getBy", col, value); err == nil {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// invalidateRow evicts the cached Get/Exists/GetBy entries for row's primary
+// key and any registered secondary-index columns.
+func (c *CRUD[T]) invalidateRow(ctx context.Context, table *schema.Table, row reflect.Value) {
+	var keys []string
+	for _, col := range append([]string{"id"}, c.cacheIndexes...) {
+		field, ok := table.FieldMap[col]
+		if !ok {
+			continue
+		}
+		keys = append(keys, c.lookupKeys(table, col, field.Value(row).Interface())...)
+	}
+	if len(keys) > 0 {
+		_ = c.cache.Del(ctx, keys...)
+	}
+}
+
+// invalidateItems evicts cached entries for every item in items.
+func (c *CRUD[T]) invalidateItems(ctx context.Context, table *schema.Table, items []T) {
+	if c.cache == nil {
+		return
+	}
+	values := reflect.ValueOf(items)
+	for i := 0; i < values.Len(); i++ {
+		c.invalidateRow(ctx, table, values.Index(i))
+	}
+}
+
+// invalidateIDs evicts cached primary-key entries for each id. Unlike
+// invalidateItems, it cannot evict registered secondary-index keys because
+// the deleted rows' field values aren't available from an id alone.
+func (c *CRUD[T]) invalidateIDs(ctx context.Context, table *schema.Table, ids []int64) {
+	if c.cache == nil {
+		return
+	}
+	var keys []string
+	for _, id := range ids {
+		keys = append(keys, c.lookupKeys(table, "id", id)...)
+	}
+	if len(keys) > 0 {
+		_ = c.cache.Del(ctx, keys...)
+	}
+}
@@ -0,0 +1,62 @@
+// Package memory provides an in-process crud.Cache implementation backed by
+// a map, suitable for a single instance or for tests.
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type entry struct {
+	value   []byte
+	expires time.Time
+}
+
+// Cache is an in-memory, process-local crud.Cache. The zero value is not
+// usable; construct one with New.
+type Cache struct {
+	mu      sync.RWMutex
+	entries map[string]entry
+}
+
+// New returns an empty in-memory cache.
+func New() *Cache {
+	return &Cache{entries: make(map[string]entry)}
+}
+
+func (c *Cache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	c.mu.RLock()
+	e, ok := c.entries[key]
+	c.mu.RUnlock()
+	if !ok {
+		return nil, false, nil
+	}
+	if !e.expires.IsZero() && time.Now().After(e.expires) {
+		c.mu.Lock()
+		delete(c.entries, key)
+		c.mu.Unlock()
+		return nil, false, nil
+	}
+	return e.value, true, nil
+}
+
+func (c *Cache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	var expires time.Time
+	if ttl > 0 {
+		expires = time.Now().Add(ttl)
+	}
+	c.mu.Lock()
+	c.entries[key] = entry{value: value, expires: expires}
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *Cache) Del(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+	c.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,178 @@
+package crud
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testCache is a minimal in-memory Cache good enough to exercise the
+// cache-key/invalidation logic without a real cache backend.
+type testCache struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+}
+
+func newTestCache() *testCache {
+	return &testCache{entries: make(map[string][]byte)}
+}
+
+func (c *testCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.entries[key]
+	return v, ok, nil
+}
+
+func (c *testCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = value
+	return nil
+}
+
+func (c *testCache) Del(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+	return nil
+}
+
+func TestCacheableFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter Filter
+		wantOK bool
+	}{
+		{"single equality", Filter{Where: []Condition{Eq("email", "a@b.com")}}, true},
+		{"empty where", Filter{}, false},
+		{"multiple conditions", Filter{Where: []Condition{Eq("email", "a@b.com"), Eq("id", int64(1))}}, false},
+		{"or group", WhereSlugOrId("slug", 1), false},
+		{"non-equality op", Filter{Where: []Condition{NotEq("email", "a@b.com")}}, false},
+		{"equality plus limit", Filter{Where: []Condition{Eq("email", "a@b.com")}, Limit: 10}, false},
+		{"equality plus cursor", Filter{Where: []Condition{Eq("email", "a@b.com")}, Cursor: "x"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, ok := cacheableFilter(tt.filter)
+			if ok != tt.wantOK {
+				t.Errorf("cacheableFilter(%+v) ok = %v, want %v", tt.filter, ok, tt.wantOK)
+			}
+		})
+	}
+
+	col, value, ok := cacheableFilter(Filter{Where: []Condition{Eq("email", "a@b.com")}})
+	if !ok || col != "email" || value != "a@b.com" {
+		t.Fatalf("cacheableFilter: got (%q, %v, %v), want (email, a@b.com, true)", col, value, ok)
+	}
+}
+
+func TestCached_HitAvoidsCallingFn(t *testing.T) {
+	ctx := context.Background()
+	c := newTestCache()
+	_ = c.Set(ctx, "k", []byte(`"cached value"`), 0)
+
+	called := false
+	result, err := cached(ctx, c, time.Minute, "k", func() (string, error) {
+		called = true
+		return "fresh value", nil
+	})
+	if err != nil {
+		t.Fatalf("cached: %v", err)
+	}
+	if called {
+		t.Error("cached: fn was called despite a cache hit")
+	}
+	if result != "cached value" {
+		t.Errorf("cached: got %q, want %q", result, "cached value")
+	}
+}
+
+func TestCached_MissPopulatesCache(t *testing.T) {
+	ctx := context.Background()
+	c := newTestCache()
+
+	result, err := cached(ctx, c, time.Minute, "k", func() (string, error) {
+		return "fresh value", nil
+	})
+	if err != nil {
+		t.Fatalf("cached: %v", err)
+	}
+	if result != "fresh value" {
+		t.Errorf("cached: got %q, want %q", result, "fresh value")
+	}
+
+	data, ok, err := c.Get(ctx, "k")
+	if err != nil || !ok {
+		t.Fatalf("cached: expected the miss to populate the cache, got ok=%v err=%v", ok, err)
+	}
+	if string(data) != `"fresh value"` {
+		t.Errorf("cached: stored %q", data)
+	}
+}
+
+func TestCached_FnErrorIsNotCached(t *testing.T) {
+	ctx := context.Background()
+	c := newTestCache()
+	wantErr := errors.New("boom")
+
+	_, err := cached(ctx, c, time.Minute, "k", func() (string, error) {
+		return "", wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("cached: got %v, want %v", err, wantErr)
+	}
+	if _, ok, _ := c.Get(ctx, "k"); ok {
+		t.Error("cached: an error result should not be cached")
+	}
+}
+
+func TestInvalidateRow_EvictsPrimaryAndIndexedKeys(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	cache := newTestCache()
+	c := New[testModel](db, WithCache[testModel](cache, time.Minute), WithCacheIndexes[testModel]("email"))
+	table := c.table()
+
+	row := testModel{ID: 1, Email: "a@b.com"}
+
+	idKeys := c.lookupKeys(table, "id", row.ID)
+	emailKeys := c.lookupKeys(table, "email", row.Email)
+	for _, key := range append(idKeys, emailKeys...) {
+		_ = cache.Set(ctx, key, []byte("x"), time.Minute)
+	}
+
+	c.invalidateRow(ctx, table, reflect.ValueOf(row))
+
+	for _, key := range append(idKeys, emailKeys...) {
+		if _, ok, _ := cache.Get(ctx, key); ok {
+			t.Errorf("invalidateRow: key %q was not evicted", key)
+		}
+	}
+}
+
+func TestInvalidateIDs_EvictsPrimaryKeys(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	cache := newTestCache()
+	c := New[testModel](db, WithCache[testModel](cache, time.Minute))
+	table := c.table()
+
+	idKeys := c.lookupKeys(table, "id", int64(1))
+	for _, key := range idKeys {
+		_ = cache.Set(ctx, key, []byte("x"), time.Minute)
+	}
+
+	c.invalidateIDs(ctx, table, []int64{1})
+
+	for _, key := range idKeys {
+		if _, ok, _ := cache.Get(ctx, key); ok {
+			t.Errorf("invalidateIDs: key %q was not evicted", key)
+		}
+	}
+}
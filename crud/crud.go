@@ -3,91 +3,372 @@ package crud
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
 )
 
-func New[T any](db *bun.DB) CRUD[T] {
-	return CRUD[T]{db: db}
+func New[T any](db *bun.DB, opts ...Option[T]) CRUD[T] {
+	c := CRUD[T]{db: db, exec: db}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
 }
 
 type CRUD[T any] struct {
-	db *bun.DB
+	db   *bun.DB
+	exec bun.IDB
+
+	softDelete     bool
+	optimisticLock bool
+
+	cache        Cache
+	cacheTTL     time.Duration
+	cacheIndexes []string
+
+	hooks []Hook[T]
 }
 
-// Add inserts a new record using Bun's NewInsert.
+// WithTx returns a copy of c that runs every operation against tx instead of
+// the underlying *bun.DB, so a caller can compose several Create/Update/Delete
+// calls into one transaction.
+func (c *CRUD[T]) WithTx(tx bun.IDB) CRUD[T] {
+	cp := *c
+	cp.exec = tx
+	return cp
+}
+
+// RunInTx runs fn with a CRUD[T] scoped to a single transaction, committing
+// when fn returns nil and rolling back otherwise.
+func (c *CRUD[T]) RunInTx(ctx context.Context, fn func(CRUD[T]) error) error {
+	return c.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return fn(c.WithTx(tx))
+	})
+}
+
+// Create inserts a new record using Bun's NewInsert.
 func (c *CRUD[T]) Create(ctx context.Context, a T) error {
-	_, err := c.db.NewInsert().Model(a).Exec(ctx)
+	return c.CreateMany(ctx, []T{a})
+}
+
+// CreateMany inserts several records with a single NewInsert statement.
+func (c *CRUD[T]) CreateMany(ctx context.Context, items []T) error {
+	if len(items) == 0 {
+		return nil
+	}
+	if err := c.runBeforeCreate(ctx, items); err != nil {
+		return err
+	}
+
+	table := c.table()
+	stampTimestamps(table, items, true)
+	_, err := c.exec.NewInsert().Model(&items).Exec(ctx)
 	if err != nil {
-		return fmt.Errorf("insert error: %w", err)
+		err = fmt.Errorf("insert error: %w", err)
+	} else {
+		c.invalidateItems(ctx, table, items)
 	}
-	return nil
+	return c.runAfterCreate(ctx, items, err)
 }
 
 // Update modifies an existing record. Bun will use the model’s primary key.
+// When optimistic locking is enabled, it also conditions on the model's
+// current Version and returns ErrStaleObject if no row matched.
 func (c *CRUD[T]) Update(ctx context.Context, a T) error {
-	_, err := c.db.NewUpdate().Model(a).Exec(ctx)
+	table := c.table()
+	if c.optimisticLock && hasColumn(table, versionColumn) {
+		return c.updateWithLock(ctx, table, a)
+	}
+	return c.UpdateMany(ctx, []T{a})
+}
+
+// UpdateMany updates several records with a single bulk NewUpdate statement.
+func (c *CRUD[T]) UpdateMany(ctx context.Context, items []T) error {
+	if len(items) == 0 {
+		return nil
+	}
+	if err := c.runBeforeUpdate(ctx, items); err != nil {
+		return err
+	}
+
+	table := c.table()
+	stampTimestamps(table, items, false)
+	_, err := c.exec.NewUpdate().Model(&items).Bulk().Exec(ctx)
 	if err != nil {
-		return fmt.Errorf("update error: %w", err)
+		err = fmt.Errorf("update error: %w", err)
+	} else {
+		c.invalidateItems(ctx, table, items)
 	}
-	return nil
+	return c.runAfterUpdate(ctx, items, err)
 }
 
-// Delete removes a record by its id.
+// updateWithLock updates a single record, requiring its version column to
+// still match the value on a, then bumps it.
+func (c *CRUD[T]) updateWithLock(ctx context.Context, table *schema.Table, a T) error {
+	items := []T{a}
+	if err := c.runBeforeUpdate(ctx, items); err != nil {
+		return err
+	}
+	a = items[0]
+
+	stampTimestamps(table, items, false)
+	a = items[0]
+
+	field := table.FieldMap[versionColumn].Value(reflect.ValueOf(&a).Elem())
+	current := field.Int()
+	field.SetInt(current + 1)
+
+	res, err := c.exec.NewUpdate().Model(&a).WherePK().Where("? = ?", bun.Ident(versionColumn), current).Exec(ctx)
+	if err == nil {
+		var affected int64
+		affected, err = res.RowsAffected()
+		if err == nil && affected == 0 {
+			err = ErrStaleObject
+		}
+	}
+	if err != nil {
+		if err != ErrStaleObject {
+			err = fmt.Errorf("update error: %w", err)
+		}
+	} else if c.cache != nil {
+		c.invalidateRow(ctx, table, reflect.ValueOf(&a).Elem())
+	}
+	return c.runAfterUpdate(ctx, []T{a}, err)
+}
+
+// Delete removes a record by its id, or soft-deletes it when soft delete is
+// enabled.
 func (c *CRUD[T]) Delete(ctx context.Context, id int64) error {
-	// create a zero value instance to infer the table/model
-	var m T
-	_, err := c.db.NewDelete().Model(&m).Where("id = ?", id).Exec(ctx)
+	return c.DeleteMany(ctx, []int64{id})
+}
+
+// DeleteMany removes several records by id with a single NewDelete statement,
+// or soft-deletes them with a single NewUpdate statement when soft delete is
+// enabled.
+func (c *CRUD[T]) DeleteMany(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := c.runBeforeDelete(ctx, ids); err != nil {
+		return err
+	}
+
+	table := c.table()
+	var err error
+	if c.softDelete && isSoftDeletable[T](table) {
+		var m T
+		_, err = c.exec.NewUpdate().Model(&m).
+			Set("? = ?", bun.Ident(softDeleteColumn(table)), time.Now()).
+			Where("id IN (?)", bun.In(ids)).
+			Exec(ctx)
+	} else {
+		// create a zero value instance to infer the table/model
+		var m T
+		_, err = c.exec.NewDelete().Model(&m).Where("id IN (?)", bun.In(ids)).Exec(ctx)
+	}
 	if err != nil {
-		return fmt.Errorf("delete error: %w", err)
+		err = fmt.Errorf("delete error: %w", err)
+	} else {
+		c.invalidateIDs(ctx, table, ids)
 	}
-	return nil
+	return c.runAfterDelete(ctx, ids, err)
 }
 
 // List retrieves multiple records based on the provided filter.
 func (c *CRUD[T]) List(ctx context.Context, filter Filter) ([]T, error) {
+	table := c.table()
+	if c.softDelete {
+		filter = withSoftDeleteFilter[T](table, filter)
+	}
+	args := QueryArgs{Filter: &filter}
+	if err := c.runBeforeQuery(ctx, OpList, &args); err != nil {
+		return nil, err
+	}
+	filter = *args.Filter
+
 	var results []T
-	query := c.db.NewSelect().Model(&results).Order("id DESC")
-	query = applyFilter(query, filter)
-	err := query.Scan(ctx)
+	query, err := applyFilter(c.exec.NewSelect().Model(&results), table, filter)
+	if err == nil {
+		err = query.Scan(ctx)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("list error: %w", err)
+		err = fmt.Errorf("list error: %w", err)
 	}
-	return results, nil
+	return results, c.runAfterQuery(ctx, OpList, &args, err)
 }
 
-// Get retrieves a single record based on the provided filter.
+// Get retrieves a single record based on the provided filter. When caching
+// is enabled, it serves from cache on a hit and populates the cache on a
+// miss.
 func (c *CRUD[T]) Get(ctx context.Context, filter Filter) (T, error) {
+	table := c.table()
+	cacheFilter := filter
+	queryFilter := filter
+	if c.softDelete {
+		queryFilter = withSoftDeleteFilter[T](table, queryFilter)
+	}
+	args := QueryArgs{Filter: &queryFilter}
+	if err := c.runBeforeQuery(ctx, OpGet, &args); err != nil {
+		var zero T
+		return zero, err
+	}
+	if args.Filter != &queryFilter {
+		// A hook replaced the filter outright (e.g. tenant scoping) instead
+		// of mutating queryFilter in place; re-sync so the query and cache
+		// key reflect the hook's filter rather than the stale original.
+		queryFilter = *args.Filter
+		cacheFilter = queryFilter
+	}
+
+	fetch := func() (T, error) {
+		var result T
+		query, err := applyFilter(c.exec.NewSelect().Model(&result), table, queryFilter)
+		if err != nil {
+			return result, fmt.Errorf("get error: %w", err)
+		}
+		if err := query.Scan(ctx); err != nil {
+			return result, fmt.Errorf("get error: %w", err)
+		}
+		return result, nil
+	}
+
 	var result T
-	query := c.db.NewSelect().Model(&result)
-	query = applyFilter(query, filter)
-	err := query.Scan(ctx)
-	if err != nil {
-		return result, fmt.Errorf("get error: %w", err)
+	var err error
+	// The cache key is derived from cacheFilter, the caller's filter before
+	// withSoftDeleteFilter prepends its own condition, so it lines up with the
+	// keys lookupKeys computes for invalidation - otherwise a soft-delete-enabled
+	// CRUD[T] would never evict a cached Get on Create/Update/Delete. Only the
+	// single-column equality shape cacheableFilter recognizes is ever cached;
+	// see its doc comment for why.
+	switch col, value, ok := cacheableFilter(cacheFilter); {
+	case c.cache == nil || !ok:
+		result, err = fetch()
+	default:
+		key, keyErr := cacheKey(table.Name, "get", Filter{Where: []Condition{Eq(col, value)}})
+		if keyErr != nil {
+			result, err = fetch()
+		} else {
+			result, err = cached(ctx, c.cache, c.cacheTTL, key, fetch)
+		}
 	}
-	return result, nil
+	return result, c.runAfterQuery(ctx, OpGet, &args, err)
 }
 
-func (s *CRUD[T]) Exists(ctx context.Context, filter Filter) bool {
-	var (
-		result T
-		id     int64
-	)
-	query := applyFilter(s.db.NewSelect().Model(&result), filter)
-	err := query.Scan(ctx, &id)
-	if err != nil {
+func (c *CRUD[T]) Exists(ctx context.Context, filter Filter) bool {
+	table := c.table()
+	cacheFilter := filter
+	queryFilter := filter
+	if c.softDelete {
+		queryFilter = withSoftDeleteFilter[T](table, queryFilter)
+	}
+	args := QueryArgs{Filter: &queryFilter}
+	if err := c.runBeforeQuery(ctx, OpExists, &args); err != nil {
+		_ = c.runAfterQuery(ctx, OpExists, &args, err)
+		return false
+	}
+	if args.Filter != &queryFilter {
+		// See the matching comment in Get: a hook may have replaced the
+		// filter outright rather than mutating queryFilter in place.
+		queryFilter = *args.Filter
+		cacheFilter = queryFilter
+	}
+
+	query := func() bool {
+		var (
+			result T
+			id     int64
+		)
+		q, err := applyFilter(c.exec.NewSelect().Model(&result), table, queryFilter)
+		if err != nil {
+			return false
+		}
+		if err := q.Scan(ctx, &id); err != nil {
+			return false
+		}
+		return id > 0
+	}
+
+	var exists bool
+	// See the matching comments in Get: key off cacheFilter so this matches
+	// lookupKeys' invalidation key, not queryFilter's soft-delete condition,
+	// and only ever cache the single-column equality shape cacheableFilter
+	// recognizes.
+	switch col, value, ok := cacheableFilter(cacheFilter); {
+	case c.cache == nil || !ok:
+		exists = query()
+	default:
+		key, keyErr := cacheKey(table.Name, "exists", Filter{Where: []Condition{Eq(col, value)}})
+		switch {
+		case keyErr != nil:
+			exists = query()
+		default:
+			if data, ok, err := c.cache.Get(ctx, key); err == nil && ok {
+				exists = len(data) == 1 && data[0] == 1
+			} else {
+				exists = query()
+				_ = c.cache.Set(ctx, key, []byte{boolByte(exists)}, c.cacheTTL)
+			}
+		}
+	}
+	if err := c.runAfterQuery(ctx, OpExists, &args, nil); err != nil {
 		return false
 	}
-	return id > 0
+	return exists
+}
+
+func boolByte(exists bool) byte {
+	if exists {
+		return 1
+	}
+	return 0
 }
 
 // GetBy retrieves a single record that matches the given field and value.
+// When caching is enabled, it serves from cache on a hit and populates the
+// cache on a miss.
 func (c *CRUD[T]) GetBy(ctx context.Context, field string, value any) (T, error) {
+	table := c.table()
+	if !validColumn(table, field) {
+		var zero T
+		return zero, fmt.Errorf("getBy error: unknown column %q", field)
+	}
+
+	args := QueryArgs{Field: field, Value: value}
+	if err := c.runBeforeQuery(ctx, OpGetBy, &args); err != nil {
+		var zero T
+		return zero, err
+	}
+	field, value = args.Field, args.Value
+
+	fetch := func() (T, error) {
+		var result T
+		query := c.exec.NewSelect().Model(&result).Where("? = ?", bun.Ident(field), value)
+		if c.softDelete && isSoftDeletable[T](table) {
+			query = query.Where("? IS NULL", bun.Ident(softDeleteColumn(table)))
+		}
+		if err := query.Scan(ctx); err != nil {
+			return result, fmt.Errorf("getBy error: %w", err)
+		}
+		return result, nil
+	}
+
 	var result T
-	condition := fmt.Sprintf("%s = ?", field)
-	err := c.db.NewSelect().Model(&result).Where(condition, value).Scan(ctx)
-	if err != nil {
-		return result, fmt.Errorf("getBy error: %w", err)
+	var err error
+	switch key, keyErr := cacheKey(table.Name, "getBy", field, value); {
+	case c.cache == nil || keyErr != nil:
+		result, err = fetch()
+	default:
+		result, err = cached(ctx, c.cache, c.cacheTTL, key, fetch)
 	}
-	return result, nil
+	return result, c.runAfterQuery(ctx, OpGetBy, &args, err)
+}
+
+// table returns the model's registered bun schema, used to validate column
+// names before they reach a query.
+func (c *CRUD[T]) table() *schema.Table {
+	var m T
+	return c.exec.Dialect().Tables().Get(reflect.TypeOf(m))
 }
@@ -0,0 +1,72 @@
+package crud
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWithTx_SwapsExecutorPreservesOptions(t *testing.T) {
+	db := newTestDB(t)
+	c := New[testModel](db, WithSoftDelete[testModel](), WithOptimisticLock[testModel]())
+
+	tx := newTestDB(t) // stands in for a bun.Tx: any bun.IDB will do here
+	cp := c.WithTx(tx)
+
+	if cp.exec != tx {
+		t.Error("WithTx: exec was not swapped to tx")
+	}
+	if c.exec == tx {
+		t.Error("WithTx: mutated the receiver instead of returning a copy")
+	}
+	if !cp.softDelete || !cp.optimisticLock {
+		t.Errorf("WithTx: options not preserved, got softDelete=%v optimisticLock=%v", cp.softDelete, cp.optimisticLock)
+	}
+}
+
+func TestCreateMany_EmptyIsNoop(t *testing.T) {
+	var c CRUD[testModel]
+	called := false
+	c.Use(Hook[testModel]{BeforeCreate: func(ctx context.Context, items []testModel) error {
+		called = true
+		return nil
+	}})
+
+	if err := c.CreateMany(context.Background(), nil); err != nil {
+		t.Fatalf("CreateMany(nil): %v", err)
+	}
+	if called {
+		t.Error("CreateMany(nil): should return before running hooks or touching the database")
+	}
+}
+
+func TestUpdateMany_EmptyIsNoop(t *testing.T) {
+	var c CRUD[testModel]
+	called := false
+	c.Use(Hook[testModel]{BeforeUpdate: func(ctx context.Context, items []testModel) error {
+		called = true
+		return nil
+	}})
+
+	if err := c.UpdateMany(context.Background(), nil); err != nil {
+		t.Fatalf("UpdateMany(nil): %v", err)
+	}
+	if called {
+		t.Error("UpdateMany(nil): should return before running hooks or touching the database")
+	}
+}
+
+func TestDeleteMany_EmptyIsNoop(t *testing.T) {
+	var c CRUD[testModel]
+	called := false
+	c.Use(Hook[testModel]{BeforeDelete: func(ctx context.Context, ids []int64) error {
+		called = true
+		return nil
+	}})
+
+	if err := c.DeleteMany(context.Background(), nil); err != nil {
+		t.Fatalf("DeleteMany(nil): %v", err)
+	}
+	if called {
+		t.Error("DeleteMany(nil): should return before running hooks or touching the database")
+	}
+}
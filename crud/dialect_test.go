@@ -0,0 +1,99 @@
+package crud
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/dialect/feature"
+	"github.com/uptrace/bun/schema"
+)
+
+// testDialect is a minimal schema.Dialect, good enough to build bun queries
+// and inspect their generated SQL via SelectQuery.String, without a real
+// database connection.
+type testDialect struct {
+	schema.BaseDialect
+	tables *schema.Tables
+}
+
+func newTestDialect() *testDialect {
+	d := &testDialect{}
+	d.tables = schema.NewTables(d)
+	return d
+}
+
+func (d *testDialect) Init(*sql.DB) {}
+
+// Name deliberately avoids dialect.Invalid: bun treats that as "no real
+// dialect" and renders queries as unsubstituted "?" templates, which isn't
+// useful for tests that want to assert on the final SQL.
+func (d *testDialect) Name() dialect.Name        { return dialect.SQLite }
+func (d *testDialect) Features() feature.Feature { return feature.Returning }
+func (d *testDialect) Tables() *schema.Tables    { return d.tables }
+func (d *testDialect) OnTable(*schema.Table)     {}
+func (d *testDialect) IdentQuote() byte          { return '"' }
+func (d *testDialect) DefaultVarcharLen() int    { return 0 }
+func (d *testDialect) DefaultSchema() string     { return "test" }
+
+func (d *testDialect) AppendSequence(b []byte, _ *schema.Table, _ *schema.Field) []byte {
+	return b
+}
+
+// newTestDB returns a *bun.DB wired to testDialect: enough to build queries
+// and render their SQL, but never meant to be Exec'd or Scan'd.
+func newTestDB(t *testing.T) *bun.DB {
+	t.Helper()
+	return bun.NewDB(nil, newTestDialect())
+}
+
+// tableFor returns T's registered bun schema against db, the same way
+// CRUD[T].table does, for tests that need it without constructing a CRUD[T].
+func tableFor[T any](db *bun.DB) *schema.Table {
+	c := New[T](db)
+	return c.table()
+}
+
+// testModel is the model the filter/page tests build queries against.
+type testModel struct {
+	bun.BaseModel `bun:"table:test_models"`
+
+	ID        int64 `bun:",pk,autoincrement"`
+	Email     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt *time.Time
+	Version   int64
+}
+
+// taggedSoftDeleteModel marks its soft-delete column with a
+// `bun:",soft_delete"` tag instead of relying on the "deleted_at" name.
+type taggedSoftDeleteModel struct {
+	bun.BaseModel `bun:"table:tagged_soft_delete_models"`
+
+	ID        int64      `bun:",pk,autoincrement"`
+	RemovedAt *time.Time `bun:",soft_delete"`
+}
+
+// interfaceSoftDeleteModel implements SoftDeletable on its pointer type,
+// the only receiver for which SetDeletedAt can have any effect.
+type interfaceSoftDeleteModel struct {
+	bun.BaseModel `bun:"table:interface_soft_delete_models"`
+
+	ID      int64 `bun:",pk,autoincrement"`
+	Removed *time.Time
+}
+
+func (m *interfaceSoftDeleteModel) GetDeletedAt() *time.Time  { return m.Removed }
+func (m *interfaceSoftDeleteModel) SetDeletedAt(t *time.Time) { m.Removed = t }
+
+// plainModel has neither a soft-delete tag, interface, nor "deleted_at"
+// column.
+type plainModel struct {
+	bun.BaseModel `bun:"table:plain_models"`
+
+	ID   int64 `bun:",pk,autoincrement"`
+	Name string
+}
@@ -1,84 +1,362 @@
 package crud
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
 )
 
-type KV[V any] struct {
-	Key   string
-	Value V
+// Op is a predicate operator understood by applyFilter.
+type Op string
+
+const (
+	OpEq        Op = "="
+	OpNeq       Op = "!="
+	OpLt        Op = "<"
+	OpLte       Op = "<="
+	OpGt        Op = ">"
+	OpGte       Op = ">="
+	OpIn        Op = "IN"
+	OpNotIn     Op = "NOT IN"
+	OpBetween   Op = "BETWEEN"
+	OpLike      Op = "LIKE"
+	OpILike     Op = "ILIKE"
+	OpIsNull    Op = "IS NULL"
+	OpIsNotNull Op = "IS NOT NULL"
+)
+
+// Conjunction joins the Children of a group Condition.
+type Conjunction string
+
+const (
+	ConjAnd Conjunction = "AND"
+	ConjOr  Conjunction = "OR"
+	ConjNot Conjunction = "NOT"
+)
+
+// Condition is a node in a predicate tree. A leaf condition sets Field, Op
+// and Value; a group condition sets Conjunction and Children instead and
+// combines its children with AND, OR, or (for a single child) NOT.
+type Condition struct {
+	Field string
+	Op    Op
+	Value any
+
+	Conjunction Conjunction
+	Children    []Condition
+}
+
+// Eq builds a Field = Value condition.
+func Eq(field string, value any) Condition { return Condition{Field: field, Op: OpEq, Value: value} }
+
+// NotEq builds a Field != Value condition.
+func NotEq(field string, value any) Condition {
+	return Condition{Field: field, Op: OpNeq, Value: value}
+}
+
+// Lt builds a Field < Value condition.
+func Lt(field string, value any) Condition { return Condition{Field: field, Op: OpLt, Value: value} }
+
+// Lte builds a Field <= Value condition.
+func Lte(field string, value any) Condition {
+	return Condition{Field: field, Op: OpLte, Value: value}
+}
+
+// Gt builds a Field > Value condition.
+func Gt(field string, value any) Condition { return Condition{Field: field, Op: OpGt, Value: value} }
+
+// Gte builds a Field >= Value condition.
+func Gte(field string, value any) Condition {
+	return Condition{Field: field, Op: OpGte, Value: value}
+}
+
+// In builds a Field IN (values...) condition. values is typically a slice;
+// it is expanded safely via bun.In.
+func In(field string, values any) Condition {
+	return Condition{Field: field, Op: OpIn, Value: values}
+}
+
+// NotIn builds a Field NOT IN (values...) condition.
+func NotIn(field string, values any) Condition {
+	return Condition{Field: field, Op: OpNotIn, Value: values}
+}
+
+// Between builds a Field BETWEEN low AND high condition.
+func Between(field string, low, high any) Condition {
+	return Condition{Field: field, Op: OpBetween, Value: [2]any{low, high}}
+}
+
+// Like builds a Field LIKE pattern condition.
+func Like(field, pattern string) Condition {
+	return Condition{Field: field, Op: OpLike, Value: pattern}
+}
+
+// ILike builds a Field ILIKE pattern condition.
+func ILike(field, pattern string) Condition {
+	return Condition{Field: field, Op: OpILike, Value: pattern}
+}
+
+// IsNull builds a Field IS NULL condition.
+func IsNull(field string) Condition { return Condition{Field: field, Op: OpIsNull} }
+
+// IsNotNull builds a Field IS NOT NULL condition.
+func IsNotNull(field string) Condition { return Condition{Field: field, Op: OpIsNotNull} }
+
+// And groups conditions so that all of them must match.
+func And(conds ...Condition) Condition {
+	return Condition{Conjunction: ConjAnd, Children: conds}
+}
+
+// Or groups conditions so that at least one of them must match.
+func Or(conds ...Condition) Condition {
+	return Condition{Conjunction: ConjOr, Children: conds}
 }
 
+// Not negates a single condition.
+func Not(cond Condition) Condition {
+	return Condition{Conjunction: ConjNot, Children: []Condition{cond}}
+}
+
+// OrderBy names a column used to order List/ListPage results.
+type OrderBy struct {
+	Column string
+	Desc   bool
+}
+
+// errMixedOrderDirection is returned by ListPage when OrderBy mixes Asc and
+// Desc columns: seekExpr's keyset predicate compares the cursor columns as a
+// single tuple against a single direction, which only yields the correct
+// window when every column sorts the same way.
+var errMixedOrderDirection = errors.New("crud: ListPage requires all OrderBy columns to share the same direction")
+
+// sameDirection reports whether every OrderBy in order sorts the same way.
+func sameDirection(order []OrderBy) bool {
+	for _, o := range order[1:] {
+		if o.Desc != order[0].Desc {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter describes the predicate, ordering and pagination applied by List,
+// ListPage, Get and Exists. Where holds a predicate tree that is combined
+// with AND at the top level; use And/Or/Not to build more elaborate trees.
 type Filter struct {
-	OrInt64     []KV[int64]
-	OrInt       []KV[int]
-	OrString    []KV[string]
-	AndInt      []KV[int]
-	AndInt64    []KV[int64]
-	AndString   []KV[string]
-	WhereInt    []KV[int]
-	WhereInt64  []KV[int64]
-	WhereString []KV[string]
-	Limit       int
-	Offset      int
-}
-
-// applyFilter applies the filter conditions to the query.
-func applyFilter(query *bun.SelectQuery, filter Filter) *bun.SelectQuery {
+	Where   []Condition
+	OrderBy []OrderBy
+	Limit   int
+	Offset  int
+
+	// WithTotal makes ListPage also run a COUNT(*) over Where and report it
+	// as Page.Total.
+	WithTotal bool
+	// Cursor resumes ListPage after the row previously returned in
+	// Page.NextCursor, using keyset pagination instead of Offset.
+	Cursor string
+
+	// IncludeDeleted includes soft-deleted rows in a soft-delete-enabled
+	// CRUD[T]'s Get/List/Exists results. It has no effect otherwise.
+	IncludeDeleted bool
+}
+
+// Filters
+func WhereEmailFilter(email string) Filter {
+	return Filter{Where: []Condition{Eq("email", email)}}
+}
+
+func WhereIdFilter(id int64) Filter {
+	return Filter{Where: []Condition{Eq("id", id)}}
+}
+
+func WhereSlugFilter(slug string) Filter {
+	return Filter{Where: []Condition{Eq("slug", slug)}}
+}
+
+func WhereSlugOrId(slug string, id int64) Filter {
+	return Filter{Where: []Condition{Or(Eq("slug", slug), Eq("id", id))}}
+}
+
+// applyFilter applies the filter's predicate tree, ordering, limit and
+// offset to the query. table is the model's registered bun schema, used to
+// reject any column name that isn't one of the model's fields before it
+// ever reaches the query string. OrderBy defaults to "id DESC" when empty,
+// matching the package's historical behavior.
+func applyFilter(query *bun.SelectQuery, table *schema.Table, filter Filter) (*bun.SelectQuery, error) {
 	if filter.Limit > 0 {
 		query = query.Limit(filter.Limit)
 	}
 	if filter.Offset > 0 {
 		query = query.Offset(filter.Offset)
 	}
-	for _, kv := range filter.AndInt {
-		query = query.Where(fmt.Sprintf("%s = ?", kv.Key), kv.Value)
+	query, err := applyWhere(query, table, filter.Where)
+	if err != nil {
+		return nil, err
+	}
+	return applyOrder(query, table, orderByOrDefault(filter.OrderBy))
+}
+
+// applyWhere applies only the predicate tree, leaving ordering and
+// pagination to the caller. ListPage uses this to run a COUNT(*) that
+// ignores Limit/Offset/OrderBy.
+func applyWhere(query *bun.SelectQuery, table *schema.Table, where []Condition) (*bun.SelectQuery, error) {
+	for _, cond := range where {
+		expr, args, err := buildExpr(table, cond)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(expr, args...)
 	}
-	for _, kv := range filter.AndInt64 {
-		query = query.Where(fmt.Sprintf("%s = ?", kv.Key), kv.Value)
+	return query, nil
+}
+
+// orderByOrDefault returns order, or the package's historical "id DESC"
+// default when order is empty.
+func orderByOrDefault(order []OrderBy) []OrderBy {
+	if len(order) > 0 {
+		return order
 	}
-	for _, kv := range filter.AndString {
-		query = query.Where(fmt.Sprintf("%s = ?", kv.Key), kv.Value)
+	return []OrderBy{{Column: "id", Desc: true}}
+}
+
+func applyOrder(query *bun.SelectQuery, table *schema.Table, order []OrderBy) (*bun.SelectQuery, error) {
+	for _, o := range order {
+		if !validColumn(table, o.Column) {
+			return nil, fmt.Errorf("crud: unknown order column %q", o.Column)
+		}
+		dir := "ASC"
+		if o.Desc {
+			dir = "DESC"
+		}
+		query = query.OrderExpr("? "+dir, bun.Ident(o.Column))
 	}
-	for _, kv := range filter.OrInt {
-		query = query.WhereOr(fmt.Sprintf("%s = ?", kv.Key), kv.Value)
+	return query, nil
+}
+
+// buildExpr renders a Condition (and, recursively, its Children) into a bun
+// placeholder expression and its positional args.
+func buildExpr(table *schema.Table, cond Condition) (string, []any, error) {
+	if len(cond.Children) > 0 {
+		return buildGroupExpr(table, cond)
 	}
-	for _, kv := range filter.OrInt64 {
-		query = query.WhereOr(fmt.Sprintf("%s = ?", kv.Key), kv.Value)
+	return buildLeafExpr(table, cond)
+}
+
+func buildGroupExpr(table *schema.Table, cond Condition) (string, []any, error) {
+	if cond.Conjunction == ConjNot {
+		if len(cond.Children) != 1 {
+			return "", nil, fmt.Errorf("crud: NOT condition expects exactly one child, got %d", len(cond.Children))
+		}
+		inner, args, err := buildExpr(table, cond.Children[0])
+		if err != nil {
+			return "", nil, err
+		}
+		return "NOT (" + inner + ")", args, nil
 	}
-	for _, kv := range filter.OrString {
-		query = query.WhereOr(fmt.Sprintf("%s = ?", kv.Key), kv.Value)
+
+	sep := " AND "
+	if cond.Conjunction == ConjOr {
+		sep = " OR "
 	}
-	for _, kv := range filter.WhereInt {
-		query = query.Where(fmt.Sprintf("%s = ?", kv.Key), kv.Value)
+
+	parts := make([]string, 0, len(cond.Children))
+	var args []any
+	for _, child := range cond.Children {
+		expr, childArgs, err := buildExpr(table, child)
+		if err != nil {
+			return "", nil, err
+		}
+		parts = append(parts, expr)
+		args = append(args, childArgs...)
 	}
-	for _, kv := range filter.WhereInt64 {
-		query = query.Where(fmt.Sprintf("%s = ?", kv.Key), kv.Value)
+	return "(" + strings.Join(parts, sep) + ")", args, nil
+}
+
+func buildLeafExpr(table *schema.Table, cond Condition) (string, []any, error) {
+	if !validColumn(table, cond.Field) {
+		return "", nil, fmt.Errorf("crud: unknown column %q", cond.Field)
 	}
-	for _, kv := range filter.WhereString {
-		query = query.Where(fmt.Sprintf("%s = ?", kv.Key), kv.Value)
+
+	switch cond.Op {
+	case OpEq, OpNeq, OpLt, OpLte, OpGt, OpGte, OpLike, OpILike:
+		return "? " + string(cond.Op) + " ?", []any{bun.Ident(cond.Field), cond.Value}, nil
+	case OpIn, OpNotIn:
+		return "? " + string(cond.Op) + " (?)", []any{bun.Ident(cond.Field), bun.In(cond.Value)}, nil
+	case OpBetween:
+		bounds, ok := cond.Value.([2]any)
+		if !ok {
+			return "", nil, fmt.Errorf("crud: BETWEEN on %q needs a [2]any{low, high} value", cond.Field)
+		}
+		return "? BETWEEN ? AND ?", []any{bun.Ident(cond.Field), bounds[0], bounds[1]}, nil
+	case OpIsNull, OpIsNotNull:
+		return "? " + string(cond.Op), []any{bun.Ident(cond.Field)}, nil
+	default:
+		return "", nil, fmt.Errorf("crud: unsupported operator %q", cond.Op)
 	}
-	return query
 }
 
-// Filters
-func WhereEmailFilter(email string) Filter {
-	return Filter{WhereString: []KV[string]{{Key: "email", Value: email}}}
+// validColumn reports whether name is one of the model's registered bun
+// fields. A nil table (no model registered yet) is treated as permissive so
+// callers that build a Filter before they have a live query still work.
+func validColumn(table *schema.Table, name string) bool {
+	if table == nil {
+		return true
+	}
+	_, ok := table.FieldMap[name]
+	return ok
 }
 
-func WhereIdFilter(id int64) Filter {
-	return Filter{WhereInt64: []KV[int64]{{Key: "id", Value: id}}}
+// withCursorTiebreaker returns order with "id" appended as a tiebreaker
+// OrderBy when it isn't already one of the columns, so ListPage's keyset
+// cursor lines up with the query's actual ORDER BY: rows tied on the
+// leading column(s) still come back in a single, repeatable total order.
+// order must already satisfy sameDirection; the tiebreaker is given
+// order's own direction.
+func withCursorTiebreaker(order []OrderBy) []OrderBy {
+	for _, o := range order {
+		if o.Column == "id" {
+			return order
+		}
+	}
+	return append(order, OrderBy{Column: "id", Desc: order[0].Desc})
 }
 
-func WhereSlugFilter(slug string) Filter {
-	return Filter{WhereString: []KV[string]{{Key: "slug", Value: slug}}}
+// orderColumns extracts order's column names, in order. ListPage uses this
+// to derive the columns a keyset cursor is built from.
+func orderColumns(order []OrderBy) []string {
+	cols := make([]string, len(order))
+	for i, o := range order {
+		cols[i] = o.Column
+	}
+	return cols
 }
 
-func WhereSlugOrId(slug string, id int64) Filter {
-	return Filter{
-		WhereString: []KV[string]{{Key: "slug", Value: slug}},
-		WhereInt64:  []KV[int64]{{Key: "id", Value: id}},
+// seekExpr renders the keyset predicate "(col, ...) < (?, ...)" (or ">" when
+// order is ascending) used to resume ListPage after a cursor. It requires every
+// cursor column to share the same sort direction; ListPage rejects mixed
+// OrderBy directions before calling it.
+func seekExpr(table *schema.Table, cols []string, desc bool, values []any) (string, []any, error) {
+	idents := make([]any, len(cols))
+	placeholders := make([]string, len(cols))
+	for i, col := range cols {
+		if !validColumn(table, col) {
+			return "", nil, fmt.Errorf("crud: unknown cursor column %q", col)
+		}
+		idents[i] = bun.Ident(col)
+		placeholders[i] = "?"
+	}
+	if len(values) != len(cols) {
+		return "", nil, fmt.Errorf("crud: cursor has %d values, want %d", len(values), len(cols))
+	}
+
+	op := ">"
+	if desc {
+		op = "<"
 	}
+	tuple := strings.Join(placeholders, ", ")
+	expr := fmt.Sprintf("(%s) %s (%s)", tuple, op, tuple)
+	return expr, append(idents, values...), nil
 }
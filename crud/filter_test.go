@@ -0,0 +1,58 @@
+package crud
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplyWhere_RejectsUnknownColumn(t *testing.T) {
+	db := newTestDB(t)
+	c := New[testModel](db)
+	table := c.table()
+
+	_, err := applyWhere(db.NewSelect().Model((*testModel)(nil)), table,
+		[]Condition{Eq(`email" = '' OR 1=1 --`, "x")})
+	if err == nil {
+		t.Fatal("applyWhere: expected error for an unregistered column, got nil")
+	}
+}
+
+func TestBuildExpr_QuotesColumnsAndExpandsIn(t *testing.T) {
+	db := newTestDB(t)
+	c := New[testModel](db)
+	table := c.table()
+
+	query, err := applyWhere(db.NewSelect().Model((*testModel)(nil)), table,
+		[]Condition{And(Eq("email", "a@b.com"), In("id", []int64{1, 2, 3}))})
+	if err != nil {
+		t.Fatalf("applyWhere: %v", err)
+	}
+
+	sql := query.String()
+	if !strings.Contains(sql, `"email" = 'a@b.com'`) {
+		t.Errorf("expected a quoted email column, got %q", sql)
+	}
+	if !strings.Contains(sql, `"id" IN (1, 2, 3)`) {
+		t.Errorf("expected id's IN list expanded in place, got %q", sql)
+	}
+}
+
+func TestWhereEmailFilter(t *testing.T) {
+	f := WhereEmailFilter("a@b.com")
+	if len(f.Where) != 1 || f.Where[0].Field != "email" || f.Where[0].Op != OpEq || f.Where[0].Value != "a@b.com" {
+		t.Fatalf("WhereEmailFilter: unexpected filter %+v", f)
+	}
+}
+
+func TestWithCursorTiebreaker(t *testing.T) {
+	withID := withCursorTiebreaker([]OrderBy{{Column: "id", Desc: true}})
+	if len(withID) != 1 {
+		t.Fatalf("expected no tiebreaker added when id is already present, got %+v", withID)
+	}
+
+	without := withCursorTiebreaker([]OrderBy{{Column: "created_at", Desc: true}})
+	want := []OrderBy{{Column: "created_at", Desc: true}, {Column: "id", Desc: true}}
+	if len(without) != 2 || without[0] != want[0] || without[1] != want[1] {
+		t.Fatalf("withCursorTiebreaker: got %+v, want %+v", without, want)
+	}
+}
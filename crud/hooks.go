@@ -0,0 +1,146 @@
+package crud
+
+import "context"
+
+// OpKind identifies which CRUD[T] operation a BeforeQuery/AfterQuery hook is
+// observing.
+type OpKind string
+
+const (
+	OpGet      OpKind = "get"
+	OpList     OpKind = "list"
+	OpListPage OpKind = "listPage"
+	OpExists   OpKind = "exists"
+	OpGetBy    OpKind = "getBy"
+)
+
+// QueryArgs carries the read-path arguments a BeforeQuery/AfterQuery hook
+// may inspect or mutate before a Get, List, ListPage, Exists or GetBy call
+// reaches the database. Filter is set for Get/List/ListPage/Exists;
+// Field/Value are set for GetBy.
+type QueryArgs struct {
+	Filter *Filter
+	Field  string
+	Value  any
+}
+
+// Hook is a set of optional callbacks around CRUD[T]'s operations,
+// registered with Use. A Before callback returning an error aborts the
+// operation before it reaches the database; an After callback returning an
+// error replaces whatever error (if any) the operation produced. Before
+// callbacks may mutate the items/ids/filter they're given in place.
+type Hook[T any] struct {
+	BeforeCreate func(ctx context.Context, items []T) error
+	AfterCreate  func(ctx context.Context, items []T, err error) error
+
+	BeforeUpdate func(ctx context.Context, items []T) error
+	AfterUpdate  func(ctx context.Context, items []T, err error) error
+
+	BeforeDelete func(ctx context.Context, ids []int64) error
+	AfterDelete  func(ctx context.Context, ids []int64, err error) error
+
+	BeforeQuery func(ctx context.Context, op OpKind, args *QueryArgs) error
+	AfterQuery  func(ctx context.Context, op OpKind, args *QueryArgs, err error) error
+}
+
+// Use registers hooks, in order, around every subsequent Create, Update,
+// Delete, Get, List, Exists and GetBy call.
+func (c *CRUD[T]) Use(hooks ...Hook[T]) {
+	c.hooks = append(c.hooks, hooks...)
+}
+
+func (c *CRUD[T]) runBeforeCreate(ctx context.Context, items []T) error {
+	for _, h := range c.hooks {
+		if h.BeforeCreate == nil {
+			continue
+		}
+		if err := h.BeforeCreate(ctx, items); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *CRUD[T]) runAfterCreate(ctx context.Context, items []T, err error) error {
+	for _, h := range c.hooks {
+		if h.AfterCreate == nil {
+			continue
+		}
+		if hookErr := h.AfterCreate(ctx, items, err); hookErr != nil {
+			return hookErr
+		}
+	}
+	return err
+}
+
+func (c *CRUD[T]) runBeforeUpdate(ctx context.Context, items []T) error {
+	for _, h := range c.hooks {
+		if h.BeforeUpdate == nil {
+			continue
+		}
+		if err := h.BeforeUpdate(ctx, items); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *CRUD[T]) runAfterUpdate(ctx context.Context, items []T, err error) error {
+	for _, h := range c.hooks {
+		if h.AfterUpdate == nil {
+			continue
+		}
+		if hookErr := h.AfterUpdate(ctx, items, err); hookErr != nil {
+			return hookErr
+		}
+	}
+	return err
+}
+
+func (c *CRUD[T]) runBeforeDelete(ctx context.Context, ids []int64) error {
+	for _, h := range c.hooks {
+		if h.BeforeDelete == nil {
+			continue
+		}
+		if err := h.BeforeDelete(ctx, ids); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *CRUD[T]) runAfterDelete(ctx context.Context, ids []int64, err error) error {
+	for _, h := range c.hooks {
+		if h.AfterDelete == nil {
+			continue
+		}
+		if hookErr := h.AfterDelete(ctx, ids, err); hookErr != nil {
+			return hookErr
+		}
+	}
+	return err
+}
+
+func (c *CRUD[T]) runBeforeQuery(ctx context.Context, op OpKind, args *QueryArgs) error {
+	for _, h := range c.hooks {
+		if h.BeforeQuery == nil {
+			continue
+		}
+		if err := h.BeforeQuery(ctx, op, args); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *CRUD[T]) runAfterQuery(ctx context.Context, op OpKind, args *QueryArgs, err error) error {
+	for _, h := range c.hooks {
+		if h.AfterQuery == nil {
+			continue
+		}
+		if hookErr := h.AfterQuery(ctx, op, args, err); hookErr != nil {
+			return hookErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,110 @@
+package crud
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRunBeforeCreate_RunsInOrderAndShortCircuits(t *testing.T) {
+	var c CRUD[testModel]
+	var calls []string
+	wantErr := errors.New("boom")
+
+	c.Use(
+		Hook[testModel]{BeforeCreate: func(ctx context.Context, items []testModel) error {
+			calls = append(calls, "first")
+			return nil
+		}},
+		Hook[testModel]{BeforeCreate: func(ctx context.Context, items []testModel) error {
+			calls = append(calls, "second")
+			return wantErr
+		}},
+		Hook[testModel]{BeforeCreate: func(ctx context.Context, items []testModel) error {
+			calls = append(calls, "third")
+			return nil
+		}},
+	)
+
+	err := c.runBeforeCreate(context.Background(), nil)
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("runBeforeCreate: got %v, want %v", err, wantErr)
+	}
+	if got := []string{"first", "second"}; !equalStrings(calls, got) {
+		t.Fatalf("runBeforeCreate: hooks ran %v, want %v (third should not run)", calls, got)
+	}
+}
+
+func TestRunAfterCreate_ReplacesErrorAndShortCircuits(t *testing.T) {
+	var c CRUD[testModel]
+	var calls []string
+	wantErr := errors.New("replaced")
+
+	c.Use(
+		Hook[testModel]{AfterCreate: func(ctx context.Context, items []testModel, err error) error {
+			calls = append(calls, "first")
+			return wantErr
+		}},
+		Hook[testModel]{AfterCreate: func(ctx context.Context, items []testModel, err error) error {
+			calls = append(calls, "second")
+			return err
+		}},
+	)
+
+	err := c.runAfterCreate(context.Background(), nil, nil)
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("runAfterCreate: got %v, want %v", err, wantErr)
+	}
+	if got := []string{"first"}; !equalStrings(calls, got) {
+		t.Fatalf("runAfterCreate: hooks ran %v, want %v (second should not run once first replaces the error)", calls, got)
+	}
+}
+
+func TestRunBeforeQuery_CanMutateFilterInPlace(t *testing.T) {
+	var c CRUD[testModel]
+	c.Use(Hook[testModel]{BeforeQuery: func(ctx context.Context, op OpKind, args *QueryArgs) error {
+		args.Filter.Limit = 5
+		return nil
+	}})
+
+	filter := Filter{}
+	args := QueryArgs{Filter: &filter}
+	if err := c.runBeforeQuery(context.Background(), OpList, &args); err != nil {
+		t.Fatalf("runBeforeQuery: %v", err)
+	}
+	if filter.Limit != 5 {
+		t.Fatalf("runBeforeQuery: in-place mutation did not take effect, got Limit=%d", filter.Limit)
+	}
+}
+
+func TestListPage_RunsBeforeQueryHookAndAbortsOnError(t *testing.T) {
+	db := newTestDB(t)
+	c := New[testModel](db)
+	wantErr := errors.New("blocked by hook")
+
+	var sawOp OpKind
+	c.Use(Hook[testModel]{BeforeQuery: func(ctx context.Context, op OpKind, args *QueryArgs) error {
+		sawOp = op
+		return wantErr
+	}})
+
+	_, err := c.ListPage(context.Background(), Filter{Where: []Condition{Eq("email", "a@b.com")}})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("ListPage: got %v, want %v", err, wantErr)
+	}
+	if sawOp != OpListPage {
+		t.Fatalf("ListPage: hook saw op %q, want %q", sawOp, OpListPage)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
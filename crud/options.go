@@ -0,0 +1,123 @@
+package crud
+
+import (
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/uptrace/bun/schema"
+)
+
+// ErrStaleObject is returned by Update when optimistic locking is enabled
+// and the row's version no longer matches what the caller read.
+var ErrStaleObject = errors.New("crud: stale object")
+
+// SoftDeletable is implemented by models that expose their soft-delete
+// timestamp directly. Implement it on *T (SetDeletedAt needs a pointer
+// receiver to have any effect); CRUD detects it via (*T)(nil), not a T
+// value. CRUD also recognizes a `bun:",soft_delete"`-tagged field, or a
+// plain "deleted_at" column, on models that don't implement it.
+type SoftDeletable interface {
+	GetDeletedAt() *time.Time
+	SetDeletedAt(t *time.Time)
+}
+
+const (
+	deletedAtColumn = "deleted_at"
+	createdAtColumn = "created_at"
+	updatedAtColumn = "updated_at"
+	versionColumn   = "version"
+)
+
+// Option configures optional CRUD[T] behavior at construction time, e.g.
+// New(db, WithSoftDelete(), WithOptimisticLock()).
+type Option[T any] func(*CRUD[T])
+
+// WithSoftDelete makes Delete set the soft-delete column instead of
+// removing the row, and makes Get/List/Exists/GetBy skip soft-deleted rows
+// unless Filter.IncludeDeleted is set. It only takes effect for models with
+// a `bun:",soft_delete"`-tagged field, that implement SoftDeletable on *T,
+// or that have a "deleted_at" column.
+func WithSoftDelete[T any]() Option[T] {
+	return func(c *CRUD[T]) { c.softDelete = true }
+}
+
+// WithOptimisticLock makes Update condition on a model's "version" column
+// and bump it, returning ErrStaleObject when no row matched. It only takes
+// effect for models with a "version" column.
+func WithOptimisticLock[T any]() Option[T] {
+	return func(c *CRUD[T]) { c.optimisticLock = true }
+}
+
+// hasColumn reports whether table registers a field for the given column.
+func hasColumn(table *schema.Table, name string) bool {
+	_, ok := table.FieldMap[name]
+	return ok
+}
+
+// isSoftDeletable reports whether T should be treated as soft-deletable:
+// it carries a `bun:",soft_delete"`-tagged field, it implements
+// SoftDeletable (through a pointer receiver - the only way
+// SetDeletedAt can have any effect), or it has a "deleted_at" column.
+func isSoftDeletable[T any](table *schema.Table) bool {
+	if table.SoftDeleteField != nil {
+		return true
+	}
+	if _, ok := any((*T)(nil)).(SoftDeletable); ok {
+		return true
+	}
+	return hasColumn(table, deletedAtColumn)
+}
+
+// softDeleteColumn returns the column soft delete should act on: the
+// `bun:",soft_delete"`-tagged field's column when the model has one, or the
+// "deleted_at" fallback otherwise.
+func softDeleteColumn(table *schema.Table) string {
+	if table.SoftDeleteField != nil {
+		return table.SoftDeleteField.Name
+	}
+	return deletedAtColumn
+}
+
+// withSoftDeleteFilter prepends a "<soft-delete column> IS NULL" condition
+// to filter unless the caller asked to see deleted rows or the model isn't
+// soft-deletable.
+func withSoftDeleteFilter[T any](table *schema.Table, filter Filter) Filter {
+	if filter.IncludeDeleted || !isSoftDeletable[T](table) {
+		return filter
+	}
+	filter.Where = append([]Condition{IsNull(softDeleteColumn(table))}, filter.Where...)
+	return filter
+}
+
+// stampTimestamps sets a "created_at" (only when creating) and
+// "updated_at" column to now on each item that has one, using reflection so
+// it works for any model shape.
+func stampTimestamps[T any](table *schema.Table, items []T, creating bool) {
+	now := time.Now()
+	createdAt, hasCreatedAt := table.FieldMap[createdAtColumn]
+	updatedAt, hasUpdatedAt := table.FieldMap[updatedAtColumn]
+	if !creating && !hasUpdatedAt {
+		return
+	}
+
+	values := reflect.ValueOf(items)
+	for i := 0; i < values.Len(); i++ {
+		row := values.Index(i)
+		if creating && hasCreatedAt {
+			setTimeField(createdAt.Value(row), now)
+		}
+		if hasUpdatedAt {
+			setTimeField(updatedAt.Value(row), now)
+		}
+	}
+}
+
+func setTimeField(field reflect.Value, now time.Time) {
+	switch field.Interface().(type) {
+	case time.Time:
+		field.Set(reflect.ValueOf(now))
+	case *time.Time:
+		field.Set(reflect.ValueOf(&now))
+	}
+}
@@ -0,0 +1,83 @@
+package crud
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsSoftDeletable(t *testing.T) {
+	db := newTestDB(t)
+
+	tests := []struct {
+		name string
+		ok   bool
+	}{
+		{"soft_delete tag", isSoftDeletable[taggedSoftDeleteModel](tableFor[taggedSoftDeleteModel](db))},
+		{"SoftDeletable interface", isSoftDeletable[interfaceSoftDeleteModel](tableFor[interfaceSoftDeleteModel](db))},
+		{"deleted_at column fallback", isSoftDeletable[testModel](tableFor[testModel](db))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.ok {
+				t.Error("isSoftDeletable: expected true")
+			}
+		})
+	}
+
+	if isSoftDeletable[plainModel](tableFor[plainModel](db)) {
+		t.Error("isSoftDeletable: expected false for a model with no soft-delete signal")
+	}
+}
+
+func TestSoftDeleteColumn(t *testing.T) {
+	db := newTestDB(t)
+
+	taggedTable := tableFor[taggedSoftDeleteModel](db)
+	if got := softDeleteColumn(taggedTable); got != "removed_at" {
+		t.Errorf("softDeleteColumn: got %q, want %q", got, "removed_at")
+	}
+
+	plainTable := tableFor[plainModel](db)
+	if got := softDeleteColumn(plainTable); got != deletedAtColumn {
+		t.Errorf("softDeleteColumn: got %q, want fallback %q", got, deletedAtColumn)
+	}
+}
+
+func TestWithSoftDeleteFilter(t *testing.T) {
+	db := newTestDB(t)
+	table := tableFor[testModel](db)
+
+	filter := withSoftDeleteFilter[testModel](table, Filter{})
+	if len(filter.Where) != 1 || filter.Where[0].Field != deletedAtColumn || filter.Where[0].Op != OpIsNull {
+		t.Fatalf("withSoftDeleteFilter: unexpected filter %+v", filter)
+	}
+
+	included := withSoftDeleteFilter[testModel](table, Filter{IncludeDeleted: true})
+	if len(included.Where) != 0 {
+		t.Fatalf("withSoftDeleteFilter: IncludeDeleted should skip the condition, got %+v", included)
+	}
+}
+
+func TestStampTimestamps(t *testing.T) {
+	db := newTestDB(t)
+	table := tableFor[testModel](db)
+
+	items := []testModel{{}}
+	stampTimestamps(table, items, true)
+	if items[0].CreatedAt.IsZero() {
+		t.Error("stampTimestamps: CreatedAt was not set on create")
+	}
+	if items[0].UpdatedAt.IsZero() {
+		t.Error("stampTimestamps: UpdatedAt was not set on create")
+	}
+	created := items[0].CreatedAt
+
+	time.Sleep(time.Millisecond)
+	stampTimestamps(table, items, false)
+	if !items[0].CreatedAt.Equal(created) {
+		t.Error("stampTimestamps: CreatedAt should not change on update")
+	}
+	if !items[0].UpdatedAt.After(created) {
+		t.Error("stampTimestamps: UpdatedAt should advance on update")
+	}
+}
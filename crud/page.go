@@ -0,0 +1,182 @@
+package crud
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/uptrace/bun/schema"
+)
+
+// Page is the result of ListPage: the page's rows, optionally the total
+// number of rows matching the filter, and an opaque cursor for the next
+// page.
+type Page[T any] struct {
+	Items      []T
+	Total      int64
+	NextCursor string
+}
+
+// ListPage retrieves a page of records using keyset (cursor) pagination
+// instead of Filter.Offset, avoiding the O(N) scan large offsets cause.
+// Pass the returned NextCursor back as Filter.Cursor to fetch the next
+// page; set Filter.WithTotal to also populate Page.Total with a COUNT(*)
+// over Filter.Where. Like Get/List/Exists, it routes through the
+// BeforeQuery/AfterQuery hook pipeline.
+func (c *CRUD[T]) ListPage(ctx context.Context, filter Filter) (Page[T], error) {
+	table := c.table()
+	if c.softDelete {
+		filter = withSoftDeleteFilter[T](table, filter)
+	}
+	args := QueryArgs{Filter: &filter}
+	if err := c.runBeforeQuery(ctx, OpListPage, &args); err != nil {
+		var zero Page[T]
+		return zero, err
+	}
+	filter = *args.Filter
+
+	page, err := c.listPage(ctx, table, filter)
+	return page, c.runAfterQuery(ctx, OpListPage, &args, err)
+}
+
+// listPage does the actual query work for ListPage, once its filter has
+// been through the soft-delete and hook adjustments.
+func (c *CRUD[T]) listPage(ctx context.Context, table *schema.Table, filter Filter) (Page[T], error) {
+	var page Page[T]
+
+	order := orderByOrDefault(filter.OrderBy)
+	if !sameDirection(order) {
+		return page, fmt.Errorf("list page error: %w", errMixedOrderDirection)
+	}
+	order = withCursorTiebreaker(order)
+	cols := orderColumns(order)
+
+	query, err := applyWhere(c.exec.NewSelect().Model(&page.Items), table, filter.Where)
+	if err != nil {
+		return page, fmt.Errorf("list page error: %w", err)
+	}
+	query, err = applyOrder(query, table, order)
+	if err != nil {
+		return page, fmt.Errorf("list page error: %w", err)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	if filter.Cursor != "" {
+		values, err := decodeCursor(table, cols, filter.Cursor)
+		if err != nil {
+			return page, fmt.Errorf("list page error: %w", err)
+		}
+		expr, args, err := seekExpr(table, cols, order[0].Desc, values)
+		if err != nil {
+			return page, fmt.Errorf("list page error: %w", err)
+		}
+		query = query.Where(expr, args...)
+	}
+
+	if filter.WithTotal {
+		countQuery, err := applyWhere(c.exec.NewSelect().Model((*T)(nil)), table, filter.Where)
+		if err != nil {
+			return page, fmt.Errorf("list page error: %w", err)
+		}
+		total, err := countQuery.Count(ctx)
+		if err != nil {
+			return page, fmt.Errorf("list page error: %w", err)
+		}
+		page.Total = int64(total)
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return page, fmt.Errorf("list page error: %w", err)
+	}
+
+	if len(page.Items) > 0 {
+		last := reflect.ValueOf(page.Items[len(page.Items)-1])
+		cursor, err := encodeCursor(table, cols, last)
+		if err != nil {
+			return page, fmt.Errorf("list page error: %w", err)
+		}
+		page.NextCursor = cursor
+	}
+
+	return page, nil
+}
+
+// encodeCursor packs the ordering column values of the last row on a page
+// into an opaque, base64-encoded cursor for ListPage's next call.
+func encodeCursor(table *schema.Table, cols []string, row reflect.Value) (string, error) {
+	values := make([]any, len(cols))
+	for i, col := range cols {
+		field, ok := table.FieldMap[col]
+		if !ok {
+			return "", fmt.Errorf("crud: unknown cursor column %q", col)
+		}
+		values[i] = field.Value(row).Interface()
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("crud: encode cursor: %w", err)
+	}
+	return base64.URLEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor reverses encodeCursor. It decodes numeric values with
+// json.Number and converts each one against cols' field kinds in table,
+// rather than letting encoding/json pick float64 for every number, which
+// would silently lose precision for int64 ids beyond 2^53.
+func decodeCursor(table *schema.Table, cols []string, cursor string) ([]any, error) {
+	data, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("crud: decode cursor: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var raw []any
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("crud: decode cursor: %w", err)
+	}
+	if len(raw) != len(cols) {
+		return nil, fmt.Errorf("crud: cursor has %d values, want %d", len(raw), len(cols))
+	}
+
+	values := make([]any, len(raw))
+	for i, col := range cols {
+		field, ok := table.FieldMap[col]
+		if !ok {
+			return nil, fmt.Errorf("crud: unknown cursor column %q", col)
+		}
+		v, err := coerceCursorValue(field, raw[i])
+		if err != nil {
+			return nil, fmt.Errorf("crud: decode cursor column %q: %w", col, err)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// coerceCursorValue converts a JSON-decoded cursor value to field's Go kind,
+// so an int64 id round-trips exactly instead of through float64.
+func coerceCursorValue(field *schema.Field, raw any) (any, error) {
+	num, ok := raw.(json.Number)
+	if !ok {
+		return raw, nil
+	}
+	switch field.IndirectType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return num.Int64()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := num.Int64()
+		if err != nil {
+			return nil, err
+		}
+		return uint64(n), nil
+	case reflect.Float32, reflect.Float64:
+		return num.Float64()
+	default:
+		return num.Int64()
+	}
+}
@@ -0,0 +1,48 @@
+package crud
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEncodeDecodeCursor_RoundTripsInt64BeyondFloat53(t *testing.T) {
+	db := newTestDB(t)
+	c := New[testModel](db)
+	table := c.table()
+
+	const bigID int64 = 1<<53 + 1 // beyond float64's exact integer range
+	row := testModel{ID: bigID}
+
+	cursor, err := encodeCursor(table, []string{"id"}, reflect.ValueOf(row))
+	if err != nil {
+		t.Fatalf("encodeCursor: %v", err)
+	}
+
+	values, err := decodeCursor(table, []string{"id"}, cursor)
+	if err != nil {
+		t.Fatalf("decodeCursor: %v", err)
+	}
+	if got, ok := values[0].(int64); !ok || got != bigID {
+		t.Fatalf("decodeCursor: got %#v, want int64 %d", values[0], bigID)
+	}
+}
+
+func TestApplyOrder_IncludesCursorTiebreaker(t *testing.T) {
+	db := newTestDB(t)
+	c := New[testModel](db)
+	table := c.table()
+
+	// ListPage builds its ORDER BY from this same augmented order so the
+	// query's tie-breaking matches the column the cursor seeks on.
+	order := withCursorTiebreaker([]OrderBy{{Column: "created_at", Desc: true}})
+	query, err := applyOrder(db.NewSelect().Model((*testModel)(nil)), table, order)
+	if err != nil {
+		t.Fatalf("applyOrder: %v", err)
+	}
+
+	sql := query.String()
+	if !strings.Contains(sql, `"created_at" DESC`) || !strings.Contains(sql, `"id" DESC`) {
+		t.Errorf("expected ORDER BY to order by both created_at and its id tiebreaker, got %q", sql)
+	}
+}